@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// parseBackfillArgs parses the "--backfill from=YYYY-MM-DD to=YYYY-MM-DD"
+// flag value into its from/to dates. to is optional and defaults to
+// today; fields may be separated by spaces or commas.
+func parseBackfillArgs(raw string) (from, to string, err error) {
+	fields := strings.FieldsFunc(raw, func(r rune) bool { return r == ' ' || r == ',' })
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return "", "", fmt.Errorf("invalid --backfill argument %q, want key=value", field)
+		}
+		switch key {
+		case "from":
+			from = value
+		case "to":
+			to = value
+		default:
+			return "", "", fmt.Errorf("unknown --backfill key %q", key)
+		}
+	}
+	if from == "" {
+		return "", "", fmt.Errorf("--backfill requires from=YYYY-MM-DD")
+	}
+	return from, to, nil
+}
+
+// runBackfill re-fetches every region's draw for each day in [from, to]
+// (inclusive) and persists it via FetchResults. to defaults to today when
+// empty. Providers that only expose the latest draw (xosodaiphat) simply
+// return an error for past dates and are skipped for that day; providers
+// that support the requested date (minhngoc, xskt) still fill history.
+func runBackfill(from, to string) error {
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return fmt.Errorf("invalid backfill from=: %w", err)
+	}
+
+	toDate := time.Now()
+	if to != "" {
+		toDate, err = time.Parse("2006-01-02", to)
+		if err != nil {
+			return fmt.Errorf("invalid backfill to=: %w", err)
+		}
+	}
+
+	for d := fromDate; !d.After(toDate); d = d.AddDate(0, 0, 1) {
+		for _, region := range regions {
+			if _, _, err := FetchResults(region, d); err != nil {
+				fmt.Printf("⚠️ backfill %s %s: %v\n", region, d.Format("2006-01-02"), err)
+				continue
+			}
+			fmt.Printf("✅ backfilled %s %s\n", region, d.Format("2006-01-02"))
+		}
+	}
+	return nil
+}
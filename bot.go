@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tuanitpro/kqxs/internal/formatter"
+)
+
+const helpText = `🤖 Các lệnh hỗ trợ:
+/mb - Kết quả Miền Bắc hôm nay
+/mt - Kết quả Miền Trung hôm nay
+/mn - Kết quả Miền Nam hôm nay
+/today - Kết quả cả 3 miền hôm nay
+/date YYYY-MM-DD - Kết quả theo ngày
+/subscribe - Nhận kết quả hàng ngày lúc 18:30
+/unsubscribe - Ngừng nhận kết quả hàng ngày
+/watch [miền] <số...> - Theo dõi số, báo khi về (VD: /watch MB 27 39 88, mặc định miền Bắc)
+/unwatch <số...> - Hủy theo dõi số
+/watchlist - Xem danh sách số đang theo dõi
+/thongke lo <ngày> - Tần suất lô 2 số trong N ngày gần nhất (VD: /thongke lo 30)
+/gan <miền> - Các số gan lâu chưa về (VD: /gan MB)
+/cap <miền> - Các cặp số về cùng nhau nhiều nhất (VD: /cap MB)
+/history <dd/mm> - Kết quả đã lưu theo ngày cũ (VD: /history 27/12)
+/help - Hiển thị trợ giúp này`
+
+type telegramUpdate struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Text string `json:"text"`
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+}
+
+type telegramUpdatesResponse struct {
+	OK     bool             `json:"ok"`
+	Result []telegramUpdate `json:"result"`
+}
+
+// runBot long-polls getUpdates and dispatches any command it sees. It is
+// meant to run in its own goroutine alongside the cron scheduler.
+func runBot() {
+	fmt.Println("🤖 Bot started, long-polling for commands...")
+
+	var offset int64
+	for {
+		updates, err := getUpdates(offset)
+		if err != nil {
+			fmt.Println("❌ getUpdates error:", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		for _, u := range updates {
+			offset = u.UpdateID + 1
+			if u.Message == nil || u.Message.Text == "" {
+				continue
+			}
+			chatID := strconv.FormatInt(u.Message.Chat.ID, 10)
+			handleCommand(chatID, u.Message.Text)
+		}
+	}
+}
+
+func getUpdates(offset int64) ([]telegramUpdate, error) {
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/getUpdates?timeout=30&offset=%d", telegramBotToken, offset)
+
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed telegramUpdatesResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+	if !parsed.OK {
+		return nil, fmt.Errorf("getUpdates returned not ok")
+	}
+	return parsed.Result, nil
+}
+
+// handleCommand dispatches a single incoming message, mirroring the
+// switch-on-command pattern used by most Go Telegram bots.
+func handleCommand(chatID, text string) {
+	arr := strings.Fields(text)
+	if len(arr) == 0 {
+		return
+	}
+
+	switch arr[0] {
+	case "/start", "/help":
+		reply(chatID, helpText)
+
+	case "/mb":
+		sendRegionResult(chatID, "Miền Bắc", time.Now())
+	case "/mt":
+		sendRegionResult(chatID, "Miền Trung", time.Now())
+	case "/mn":
+		sendRegionResult(chatID, "Miền Nam", time.Now())
+
+	case "/today":
+		for _, region := range regions {
+			sendRegionResult(chatID, region, time.Now())
+		}
+
+	case "/date":
+		if len(arr) < 2 {
+			reply(chatID, "Cú pháp: /date YYYY-MM-DD")
+			return
+		}
+		date, err := time.Parse("2006-01-02", arr[1])
+		if err != nil {
+			reply(chatID, "❌ Ngày không hợp lệ, dùng định dạng YYYY-MM-DD")
+			return
+		}
+		for _, region := range regions {
+			sendRegionResult(chatID, region, date)
+		}
+
+	case "/subscribe":
+		if err := subscribe(chatID); err != nil {
+			reply(chatID, "❌ Không thể đăng ký: "+err.Error())
+			return
+		}
+		reply(chatID, "✅ Đã đăng ký nhận kết quả hàng ngày lúc 18:30")
+
+	case "/unsubscribe":
+		if err := unsubscribe(chatID); err != nil {
+			reply(chatID, "❌ Không thể hủy đăng ký: "+err.Error())
+			return
+		}
+		reply(chatID, "✅ Đã hủy đăng ký")
+
+	case "/watch":
+		handleWatch(chatID, arr[1:])
+
+	case "/unwatch":
+		handleUnwatch(chatID, arr[1:])
+
+	case "/watchlist":
+		handleWatchlist(chatID)
+
+	case "/thongke":
+		if len(arr) < 3 || arr[1] != "lo" {
+			reply(chatID, "Cú pháp: /thongke lo <số ngày>")
+			return
+		}
+		handleThongKeLo(chatID, arr[2])
+
+	case "/gan":
+		if len(arr) < 2 {
+			reply(chatID, "Cú pháp: /gan <miền>, VD: /gan MB")
+			return
+		}
+		handleGan(chatID, arr[1])
+
+	case "/cap":
+		if len(arr) < 2 {
+			reply(chatID, "Cú pháp: /cap <miền>, VD: /cap MB")
+			return
+		}
+		handleCap(chatID, arr[1])
+
+	case "/history":
+		if len(arr) < 2 {
+			reply(chatID, "Cú pháp: /history <dd/mm>, VD: /history 27/12")
+			return
+		}
+		handleHistory(chatID, arr[1])
+
+	default:
+		reply(chatID, "❓ Lệnh không hợp lệ. Gõ /help để xem danh sách lệnh.")
+	}
+}
+
+func sendRegionResult(chatID, region string, date time.Time) {
+	prizes, warnings, err := FetchResults(region, date)
+	if err != nil {
+		reply(chatID, fmt.Sprintf("❌ Lỗi lấy kết quả %s: %s", region, err))
+		return
+	}
+	messages, err := selectedFormatter.Format(region, date, prizes)
+	if err != nil {
+		reply(chatID, fmt.Sprintf("❌ Lỗi định dạng kết quả %s: %s", region, err))
+		return
+	}
+	if len(warnings) > 0 {
+		messages = append(messages, formatter.Message{Text: strings.Join(warnings, "\n")})
+	}
+	deliver(chatID, messages)
+}
+
+func reply(chatID, message string) {
+	if err := sendToTelegram(chatID, message, ""); err != nil {
+		fmt.Println("❌ Reply error:", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Package formatter renders a region's prizes into one or more
+// Telegram-ready messages — plain text, an escaped MarkdownV2 table, or a
+// rendered image — selectable via config.
+package formatter
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tuanitpro/kqxs/internal/providers"
+)
+
+// maxMessageLen is kept comfortably below Telegram's 4096-char hard limit
+// so a split never lands right on the boundary.
+const maxMessageLen = 4000
+
+// Message is one Telegram-ready message. If Image is non-nil it must be
+// sent via sendPhoto with Text as the caption; otherwise Text is sent via
+// sendMessage using ParseMode ("" means plain text).
+type Message struct {
+	Text      string
+	ParseMode string
+	Image     []byte
+}
+
+// Formatter renders region's prizes for date into one or more messages,
+// splitting into several if the rendered text would otherwise exceed
+// Telegram's message size limit.
+type Formatter interface {
+	Format(region string, date time.Time, prizes []providers.Prize) ([]Message, error)
+}
+
+// Config selects which Formatter New builds.
+type Config struct {
+	Kind  string // KQXS_FORMAT: "text" (default) or "markdown"
+	Image bool   // KQXS_IMAGE=1: render as a PNG instead, sent via sendPhoto
+}
+
+// New builds the Formatter selected by cfg.
+func New(cfg Config) Formatter {
+	if cfg.Image {
+		return NewImageFormatter()
+	}
+	if cfg.Kind == "markdown" {
+		return NewMarkdownFormatter()
+	}
+	return NewTextFormatter()
+}
+
+// FromEnv builds a Formatter using KQXS_FORMAT and KQXS_IMAGE.
+func FromEnv() Formatter {
+	return New(Config{
+		Kind:  os.Getenv("KQXS_FORMAT"),
+		Image: os.Getenv("KQXS_IMAGE") == "1",
+	})
+}
+
+// groupByLocation buckets prizes by Location, preserving first-seen
+// order — the same grouping the bot has always rendered results in.
+func groupByLocation(prizes []providers.Prize) ([]string, map[string][]providers.Prize) {
+	byLocation := make(map[string][]providers.Prize)
+	var order []string
+	for _, p := range prizes {
+		if _, ok := byLocation[p.Location]; !ok {
+			order = append(order, p.Location)
+		}
+		byLocation[p.Location] = append(byLocation[p.Location], p)
+	}
+	return order, byLocation
+}
+
+// splitBlocks packs blocks (each a self-contained, already-rendered chunk
+// for one location's prizes) into as few messages as possible without
+// exceeding maxMessageLen, splitting only between blocks so a prize line
+// is never cut in half. header is repeated at the top of every message
+// and footer at the end of every message (e.g. to keep a code fence
+// balanced).
+func splitBlocks(header string, blocks []string, footer string) []string {
+	var messages []string
+	var current strings.Builder
+	current.WriteString(header)
+
+	flush := func() {
+		if current.Len() > len(header) {
+			current.WriteString(footer)
+			messages = append(messages, current.String())
+		}
+	}
+
+	for _, block := range blocks {
+		if current.Len() > len(header) && current.Len()+len(block)+len(footer) > maxMessageLen {
+			flush()
+			current.Reset()
+			current.WriteString(header)
+		}
+		current.WriteString(block)
+	}
+	flush()
+
+	if len(messages) == 0 {
+		return []string{header + footer}
+	}
+	return messages
+}
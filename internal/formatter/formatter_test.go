@@ -0,0 +1,57 @@
+package formatter
+
+import "testing"
+
+func TestEscapeMarkdown(t *testing.T) {
+	tests := map[string]string{
+		"Hà Nội":   "Hà Nội",
+		"27/12":    "27/12",
+		"27.12":    `27\.12`,
+		"G.ĐB (1)": `G\.ĐB \(1\)`,
+		"a-b_c*d!": `a\-b\_c\*d\!`,
+	}
+	for in, want := range tests {
+		if got := escapeMarkdown(in); got != want {
+			t.Errorf("escapeMarkdown(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSplitBlocksFitsInOneMessage(t *testing.T) {
+	messages := splitBlocks("HEADER\n", []string{"block1\n", "block2\n"}, "FOOTER")
+	if len(messages) != 1 {
+		t.Fatalf("expected 1 message, got %d: %v", len(messages), messages)
+	}
+	want := "HEADER\nblock1\nblock2\nFOOTER"
+	if messages[0] != want {
+		t.Fatalf("message = %q, want %q", messages[0], want)
+	}
+}
+
+func TestSplitBlocksSplitsOnOverflow(t *testing.T) {
+	header := "HEADER\n"
+	footer := "FOOTER"
+	big := make([]byte, maxMessageLen-len(header)-len(footer)-1)
+	for i := range big {
+		big[i] = 'x'
+	}
+	blockA := string(big) + "\n"
+	blockB := "block2\n"
+
+	messages := splitBlocks(header, []string{blockA, blockB}, footer)
+	if len(messages) != 2 {
+		t.Fatalf("expected overflow to split into 2 messages, got %d", len(messages))
+	}
+	for _, m := range messages {
+		if len(m) > maxMessageLen {
+			t.Errorf("message exceeds maxMessageLen: %d", len(m))
+		}
+	}
+}
+
+func TestSplitBlocksNoBlocksStillBalancesFence(t *testing.T) {
+	messages := splitBlocks("HEADER\n", nil, "FOOTER")
+	if len(messages) != 1 || messages[0] != "HEADER\nFOOTER" {
+		t.Fatalf("unexpected result for no blocks: %v", messages)
+	}
+}
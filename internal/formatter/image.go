@@ -0,0 +1,82 @@
+package formatter
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tuanitpro/kqxs/internal/providers"
+)
+
+// ImageFormatter renders prizes as an HTML table and rasterizes it to a
+// PNG with wkhtmltoimage, for delivery via Telegram's sendPhoto instead of
+// sendMessage. wkhtmltoimage must be on PATH; chromedp would be a drop-in
+// alternative if that dependency is ever preferred.
+type ImageFormatter struct{}
+
+// NewImageFormatter builds an ImageFormatter.
+func NewImageFormatter() ImageFormatter { return ImageFormatter{} }
+
+func (ImageFormatter) Format(region string, date time.Time, prizes []providers.Prize) ([]Message, error) {
+	png, err := renderImage(region, date, prizes)
+	if err != nil {
+		return nil, err
+	}
+	caption := fmt.Sprintf("📢 %s - %s", region, date.Format("02/01/2006"))
+	return []Message{{Text: caption, Image: png}}, nil
+}
+
+// renderImage writes an HTML rendering of prizes to a temp file and shells
+// out to wkhtmltoimage to rasterize it, returning the resulting PNG bytes.
+func renderImage(region string, date time.Time, prizes []providers.Prize) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "kqxs-image-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	htmlPath := filepath.Join(dir, "result.html")
+	pngPath := filepath.Join(dir, "result.png")
+	if err := os.WriteFile(htmlPath, []byte(renderHTML(region, date, prizes)), 0600); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("wkhtmltoimage", "--quality", "90", "--width", "600", htmlPath, pngPath)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("wkhtmltoimage: %w: %s", err, out)
+	}
+
+	return os.ReadFile(pngPath)
+}
+
+func renderHTML(region string, date time.Time, prizes []providers.Prize) string {
+	var b strings.Builder
+	b.WriteString(`<html><head><meta charset="utf-8"><style>
+body { font-family: "DejaVu Sans", sans-serif; padding: 16px; }
+h2 { margin: 0 0 8px; }
+h3 { margin: 12px 0 4px; }
+table { border-collapse: collapse; width: 100%; }
+td { padding: 2px 8px; font-family: monospace; }
+td.position { font-weight: bold; white-space: nowrap; }
+</style></head><body>`)
+	fmt.Fprintf(&b, "<h2>%s - %s</h2>", html.EscapeString(region), html.EscapeString(date.Format("02/01/2006")))
+
+	order, byLocation := groupByLocation(prizes)
+	for _, loc := range order {
+		if loc != "" {
+			fmt.Fprintf(&b, "<h3>%s</h3>", html.EscapeString(loc))
+		}
+		b.WriteString("<table>")
+		for _, p := range byLocation[loc] {
+			fmt.Fprintf(&b, "<tr><td class=\"position\">G.%s</td><td>%s</td></tr>",
+				html.EscapeString(p.Position), html.EscapeString(strings.Join(p.Numbers, " ")))
+		}
+		b.WriteString("</table>")
+	}
+	b.WriteString("</body></html>")
+	return b.String()
+}
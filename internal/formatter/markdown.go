@@ -0,0 +1,65 @@
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tuanitpro/kqxs/internal/providers"
+)
+
+// markdownEscaper escapes the characters MarkdownV2 treats as special
+// outside of a code block, per Telegram's formatting spec.
+var markdownEscaper = strings.NewReplacer(
+	"_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`,
+	"=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// escapeMarkdown escapes s for use outside a MarkdownV2 code block.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// escapeCodeBlock escapes s for use inside a MarkdownV2 ``` code block,
+// where only a backslash and a backtick are special.
+func escapeCodeBlock(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, "`", "\\`")
+}
+
+// MarkdownFormatter renders prizes as a monospace, column-aligned table
+// inside a MarkdownV2 code block, so numbers line up on clients that
+// render code with a fixed-width font.
+type MarkdownFormatter struct{}
+
+// NewMarkdownFormatter builds a MarkdownFormatter.
+func NewMarkdownFormatter() MarkdownFormatter { return MarkdownFormatter{} }
+
+func (MarkdownFormatter) Format(region string, date time.Time, prizes []providers.Prize) ([]Message, error) {
+	header := fmt.Sprintf("📢 *%s* \\- %s\n", escapeMarkdown(region), escapeMarkdown(date.Format("02/01/2006")))
+	order, byLocation := groupByLocation(prizes)
+
+	var blocks []string
+	for _, loc := range order {
+		var raw bytes.Buffer
+		tw := tabwriter.NewWriter(&raw, 0, 4, 1, ' ', 0)
+		if loc != "" {
+			fmt.Fprintf(tw, "%s\n", loc)
+		}
+		for _, p := range byLocation[loc] {
+			fmt.Fprintf(tw, "G.%s\t%s\n", p.Position, strings.Join(p.Numbers, " "))
+		}
+		tw.Flush()
+		blocks = append(blocks, escapeCodeBlock(raw.String())+"\n")
+	}
+
+	const fence = "```\n"
+	var messages []Message
+	for _, body := range splitBlocks(header+fence, blocks, "```") {
+		messages = append(messages, Message{Text: body, ParseMode: "MarkdownV2"})
+	}
+	return messages, nil
+}
@@ -0,0 +1,40 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tuanitpro/kqxs/internal/providers"
+)
+
+// TextFormatter renders prizes as the plain, unadorned text the bot has
+// always sent — one block per location, no Telegram entities.
+type TextFormatter struct{}
+
+// NewTextFormatter builds a TextFormatter.
+func NewTextFormatter() TextFormatter { return TextFormatter{} }
+
+func (TextFormatter) Format(region string, date time.Time, prizes []providers.Prize) ([]Message, error) {
+	header := fmt.Sprintf("📢 %s\n\n", region)
+	order, byLocation := groupByLocation(prizes)
+
+	var blocks []string
+	for _, loc := range order {
+		var b strings.Builder
+		if loc != "" {
+			b.WriteString(loc + "\n")
+		}
+		for _, p := range byLocation[loc] {
+			b.WriteString(fmt.Sprintf("G.%s %s\n", p.Position, strings.Join(p.Numbers, " ")))
+		}
+		b.WriteString("\n")
+		blocks = append(blocks, b.String())
+	}
+
+	var messages []Message
+	for _, text := range splitBlocks(header, blocks, "") {
+		messages = append(messages, Message{Text: text})
+	}
+	return messages, nil
+}
@@ -0,0 +1,52 @@
+package httpclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// cacheEntry is what fileCache persists per URL: the validators needed for
+// a conditional request, plus the body they last validated.
+type cacheEntry struct {
+	ETag         string `json:"etag"`
+	LastModified string `json:"last_modified"`
+	Body         []byte `json:"body"`
+}
+
+// fileCache is a flat on-disk cache keyed by a hash of the URL.
+type fileCache struct {
+	dir string
+}
+
+func (c *fileCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *fileCache) Load(url string) (cacheEntry, bool) {
+	data, err := os.ReadFile(c.path(url))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *fileCache) Store(url string, entry cacheEntry) {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path(url), data, 0644)
+}
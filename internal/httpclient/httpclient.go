@@ -0,0 +1,179 @@
+// Package httpclient wraps http.Client with the retry, timeout, caching
+// and proxy behavior every provider needs, so none of them talk to
+// net/http directly.
+package httpclient
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+const userAgent = "kqxs-bot/1.0 (+https://github.com/tuanitpro/kqxs)"
+
+// Config configures a Client. Zero values fall back to sane defaults.
+type Config struct {
+	Timeout      time.Duration
+	Proxy        string // KQXS_PROXY: http(s)://host:port or socks5://host:port
+	CacheDir     string
+	MaxAttempts  int
+	BaseInterval time.Duration
+}
+
+// Client is an http.Client wrapper with retries, ETag/Last-Modified
+// caching and optional proxying.
+type Client struct {
+	http         *http.Client
+	cache        *fileCache
+	maxAttempts  int
+	baseInterval time.Duration
+}
+
+// New builds a Client from cfg.
+func New(cfg Config) (*Client, error) {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 15 * time.Second
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.BaseInterval == 0 {
+		cfg.BaseInterval = 10 * time.Second
+	}
+	if cfg.CacheDir == "" {
+		cfg.CacheDir = ".kqxs-cache"
+	}
+
+	transport, err := buildTransport(cfg.Proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		http:         &http.Client{Timeout: cfg.Timeout, Transport: transport},
+		cache:        &fileCache{dir: cfg.CacheDir},
+		maxAttempts:  cfg.MaxAttempts,
+		baseInterval: cfg.BaseInterval,
+	}, nil
+}
+
+// FromEnv builds a Client using KQXS_PROXY for the proxy setting.
+func FromEnv() (*Client, error) {
+	return New(Config{Proxy: os.Getenv("KQXS_PROXY")})
+}
+
+func buildTransport(proxyEnv string) (*http.Transport, error) {
+	transport := &http.Transport{}
+	if proxyEnv == "" {
+		return transport, nil
+	}
+
+	proxyURL, err := url.Parse(proxyEnv)
+	if err != nil {
+		return nil, fmt.Errorf("invalid KQXS_PROXY: %w", err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https":
+		transport.Proxy = http.ProxyURL(proxyURL)
+	case "socks5":
+		dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KQXS_PROXY: %w", err)
+		}
+		transport.Dial = dialer.Dial
+	default:
+		return nil, fmt.Errorf("unsupported KQXS_PROXY scheme %q", proxyURL.Scheme)
+	}
+	return transport, nil
+}
+
+// Get fetches url, retrying 5xx and network errors with exponential
+// backoff and jitter, and short-circuiting on a cached 304.
+func (c *Client) Get(url string) ([]byte, error) {
+	cached, hasCached := c.cache.Load(url)
+
+	var lastErr error
+	for attempt := 0; attempt < c.maxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff(c.baseInterval, attempt))
+		}
+
+		body, notModified, err := c.doRequest(url, cached, hasCached)
+		if err != nil {
+			if _, permanent := err.(*permanentError); permanent {
+				return nil, err
+			}
+			lastErr = err
+			continue
+		}
+		if notModified {
+			return cached.Body, nil
+		}
+		return body, nil
+	}
+	return nil, fmt.Errorf("httpclient: %s failed after %d attempts: %w", url, c.maxAttempts, lastErr)
+}
+
+func (c *Client) doRequest(url string, cached cacheEntry, hasCached bool) (body []byte, notModified bool, err error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if hasCached {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, true, nil
+	}
+	if resp.StatusCode >= 500 {
+		return nil, false, fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, false, &permanentError{fmt.Sprintf("%s returned %d", url, resp.StatusCode)}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, err
+	}
+
+	c.cache.Store(url, cacheEntry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Body:         data,
+	})
+	return data, false, nil
+}
+
+// permanentError marks a response (4xx) that retrying won't fix.
+type permanentError struct{ msg string }
+
+func (e *permanentError) Error() string { return e.msg }
+
+// backoff returns the base interval doubled per attempt, plus up to 50%
+// jitter, so retries from many providers don't all collide.
+func backoff(base time.Duration, attempt int) time.Duration {
+	d := base * time.Duration(1<<uint(attempt-1))
+	jitter := time.Duration(rand.Int63n(int64(d)/2 + 1))
+	return d + jitter
+}
@@ -0,0 +1,69 @@
+// Package prizes parses raw "G.<position> <numbers>" prize lines and
+// implements the different ways a watched number can match a draw.
+package prizes
+
+import (
+	"regexp"
+	"strings"
+)
+
+var lineRe = regexp.MustCompile(`^G\.(\S+?)\.?\s+(.*)$`)
+
+// Prize is one parsed prize position and the winning numbers announced
+// under it.
+type Prize struct {
+	Position string
+	Numbers  []string
+}
+
+// ParseLine parses a single "G.<position> <numbers>" line as found in the
+// upstream sources' descriptions/tables. ok is false if line isn't a
+// prize line.
+func ParseLine(line string) (Prize, bool) {
+	m := lineRe.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return Prize{}, false
+	}
+	return Prize{Position: m[1], Numbers: strings.Fields(m[2])}, true
+}
+
+// MatchMode is how a watched number should be compared against a
+// winning number.
+type MatchMode string
+
+const (
+	ModeExact MatchMode = "exact" // full number match
+	ModeHead  MatchMode = "dau"   // đầu: first 2 digits
+	ModeTail  MatchMode = "duoi"  // đuôi: last 2 digits
+	ModeLo2   MatchMode = "lo2"   // lô 2 số: last 2 digits
+	ModeLo3   MatchMode = "lo3"   // lô 3 số: last 3 digits
+)
+
+// ValidModes lists every mode accepted from user input.
+var ValidModes = []MatchMode{ModeExact, ModeHead, ModeTail, ModeLo2, ModeLo3}
+
+// IsValidMode reports whether mode is one of ValidModes.
+func IsValidMode(mode MatchMode) bool {
+	for _, m := range ValidModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether number satisfies watched under mode.
+func Matches(mode MatchMode, watched, number string) bool {
+	switch mode {
+	case ModeHead:
+		return len(number) >= 2 && number[:2] == watched
+	case ModeTail:
+		return len(number) >= 2 && number[len(number)-2:] == watched
+	case ModeLo3:
+		return len(number) >= 3 && number[len(number)-3:] == watched
+	case ModeLo2:
+		return len(number) >= 2 && number[len(number)-2:] == watched
+	default:
+		return number == watched
+	}
+}
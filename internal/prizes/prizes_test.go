@@ -0,0 +1,69 @@
+package prizes
+
+import "testing"
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		wantOK   bool
+		wantPos  string
+		wantNums []string
+	}{
+		{"G.ĐB 12345", true, "ĐB", []string{"12345"}},
+		{"G.1. 67890", true, "1", []string{"67890"}},
+		{"G.7 11 22 33 44", true, "7", []string{"11", "22", "33", "44"}},
+		{"[Hà Nội]", false, "", nil},
+		{"", false, "", nil},
+	}
+
+	for _, tt := range tests {
+		p, ok := ParseLine(tt.line)
+		if ok != tt.wantOK {
+			t.Errorf("ParseLine(%q) ok = %v, want %v", tt.line, ok, tt.wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if p.Position != tt.wantPos {
+			t.Errorf("ParseLine(%q) position = %q, want %q", tt.line, p.Position, tt.wantPos)
+		}
+		if len(p.Numbers) != len(tt.wantNums) {
+			t.Errorf("ParseLine(%q) numbers = %v, want %v", tt.line, p.Numbers, tt.wantNums)
+			continue
+		}
+		for i := range p.Numbers {
+			if p.Numbers[i] != tt.wantNums[i] {
+				t.Errorf("ParseLine(%q) numbers = %v, want %v", tt.line, p.Numbers, tt.wantNums)
+				break
+			}
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		mode    MatchMode
+		watched string
+		number  string
+		want    bool
+	}{
+		{ModeExact, "12345", "12345", true},
+		{ModeExact, "12345", "54321", false},
+		{ModeHead, "12", "12345", true},
+		{ModeHead, "23", "12345", false},
+		{ModeTail, "45", "12345", true},
+		{ModeTail, "44", "12345", false},
+		{ModeLo2, "45", "12345", true},
+		{ModeLo3, "345", "12345", true},
+		{ModeLo3, "234", "12345", false},
+		{ModeTail, "5", "5", false}, // too short for a 2-digit tail
+	}
+
+	for _, tt := range tests {
+		got := Matches(tt.mode, tt.watched, tt.number)
+		if got != tt.want {
+			t.Errorf("Matches(%s, %q, %q) = %v, want %v", tt.mode, tt.watched, tt.number, got, tt.want)
+		}
+	}
+}
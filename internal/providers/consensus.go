@@ -0,0 +1,138 @@
+package providers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ConsensusResult is the outcome of cross-validating a region's draw across
+// multiple providers.
+type ConsensusResult struct {
+	Draw     *Draw
+	Warnings []string
+}
+
+// FetchConsensus queries every provider in parallel and only publishes
+// prize numbers that at least 2 sources agree on for a given
+// (location, position). Positions where sources disagree are returned as
+// warnings instead of being silently published.
+func FetchConsensus(providers []Provider, region string, date time.Time) (*ConsensusResult, error) {
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no providers configured")
+	}
+
+	draws := make([]*Draw, len(providers))
+	var g errgroup.Group
+	for i, p := range providers {
+		i, p := i, p
+		g.Go(func() error {
+			d, err := p.Fetch(region, date)
+			if err != nil {
+				fmt.Printf("⚠️ provider %s failed for %s: %v\n", p.Name(), region, err)
+				return nil
+			}
+			draws[i] = d
+			return nil
+		})
+	}
+	_ = g.Wait() // errors are logged per-provider above, never fatal here
+
+	var valid []*Draw
+	for _, d := range draws {
+		if d != nil {
+			valid = append(valid, d)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("all providers failed for %s", region)
+	}
+	if len(valid) == 1 {
+		return &ConsensusResult{Draw: valid[0]}, nil
+	}
+
+	agreed, warnings := reconcile(valid)
+	return &ConsensusResult{
+		Draw:     &Draw{Region: region, Date: date, Prizes: agreed},
+		Warnings: warnings,
+	}, nil
+}
+
+type prizeKey struct{ location, position string }
+
+// prizeOrder ranks positions in the order they're announced (đặc biệt
+// first, then 1-8), so the rendered table always lists them the same way
+// regardless of which provider reported them first.
+var prizeOrder = []string{"ĐB", "1", "2", "3", "4", "5", "6", "7", "8"}
+
+// positionRank returns position's index in prizeOrder, or len(prizeOrder)
+// for anything not in the usual 9-position scheme so it sorts last.
+func positionRank(position string) int {
+	for i, p := range prizeOrder {
+		if p == position {
+			return i
+		}
+	}
+	return len(prizeOrder)
+}
+
+// reconcile keeps prize numbers that at least 2 sources agree on per
+// (location, position) and reports the rest as diffs. The result is
+// ordered by location then prize position, not map-iteration order,
+// since formatters render it as-is into a table.
+func reconcile(draws []*Draw) ([]Prize, []string) {
+	votes := make(map[prizeKey]map[string]int)
+
+	for _, d := range draws {
+		for _, p := range d.Prizes {
+			k := prizeKey{normalizeLocation(p.Location), normalizePosition(p.Position)}
+			if votes[k] == nil {
+				votes[k] = make(map[string]int)
+			}
+			for _, n := range p.Numbers {
+				votes[k][n]++
+			}
+		}
+	}
+
+	keys := make([]prizeKey, 0, len(votes))
+	for k := range votes {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].location != keys[j].location {
+			return keys[i].location < keys[j].location
+		}
+		ri, rj := positionRank(keys[i].position), positionRank(keys[j].position)
+		if ri != rj {
+			return ri < rj
+		}
+		return keys[i].position < keys[j].position
+	})
+
+	var agreed []Prize
+	var warnings []string
+	for _, k := range keys {
+		var numbers []string
+		var diffs []string
+		for number, count := range votes[k] {
+			if count >= 2 {
+				numbers = append(numbers, number)
+			} else {
+				diffs = append(diffs, number)
+			}
+		}
+		sort.Strings(numbers)
+		sort.Strings(diffs)
+		if len(numbers) > 0 {
+			agreed = append(agreed, Prize{Location: k.location, Position: k.position, Numbers: numbers})
+		}
+		if len(diffs) > 0 {
+			warnings = append(warnings, fmt.Sprintf("⚠️ nguồn chưa khớp [%s G.%s]: %s", k.location, k.position, strings.Join(diffs, ", ")))
+		}
+	}
+	return agreed, warnings
+}
@@ -0,0 +1,85 @@
+package providers
+
+import "testing"
+
+// TestReconcileNormalizesAcrossSources proves two differently-formatted
+// sources (xosodaiphat's bracketed location + short position code vs
+// minhngoc's clean location + full Vietnamese label) still agree once
+// reconcile normalizes their keys.
+func TestReconcileNormalizesAcrossSources(t *testing.T) {
+	xosodaiphat := &Draw{Prizes: []Prize{
+		{Location: "[Hà Nội]", Position: "ĐB", Numbers: []string{"12345"}},
+	}}
+	minhngoc := &Draw{Prizes: []Prize{
+		{Location: "Hà Nội", Position: "Giải đặc biệt", Numbers: []string{"12345"}},
+	}}
+
+	agreed, warnings := reconcile([]*Draw{xosodaiphat, minhngoc})
+
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+	if len(agreed) != 1 {
+		t.Fatalf("expected 1 agreed prize, got %d: %v", len(agreed), agreed)
+	}
+	if agreed[0].Location != "Hà Nội" || agreed[0].Position != "ĐB" {
+		t.Fatalf("unexpected canonical key: %+v", agreed[0])
+	}
+	if len(agreed[0].Numbers) != 1 || agreed[0].Numbers[0] != "12345" {
+		t.Fatalf("unexpected numbers: %v", agreed[0].Numbers)
+	}
+}
+
+// TestReconcileDisagreementIsWarned proves a single-source number is
+// reported as a warning instead of being silently published.
+func TestReconcileDisagreementIsWarned(t *testing.T) {
+	xosodaiphat := &Draw{Prizes: []Prize{
+		{Location: "[Hà Nội]", Position: "1", Numbers: []string{"111"}},
+	}}
+	minhngoc := &Draw{Prizes: []Prize{
+		{Location: "Hà Nội", Position: "Giải nhất", Numbers: []string{"222"}},
+	}}
+
+	agreed, warnings := reconcile([]*Draw{xosodaiphat, minhngoc})
+
+	if len(agreed) != 0 {
+		t.Fatalf("expected no agreed prizes, got %v", agreed)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %v", warnings)
+	}
+}
+
+// TestReconcileOrdersDeterministically proves agreed is ordered by
+// location then prize position rather than Go's randomized map-iteration
+// order, so the rendered table doesn't reshuffle between runs.
+func TestReconcileOrdersDeterministically(t *testing.T) {
+	a := &Draw{Prizes: []Prize{
+		{Location: "TP.HCM", Position: "7", Numbers: []string{"11"}},
+		{Location: "TP.HCM", Position: "ĐB", Numbers: []string{"99999"}},
+		{Location: "Cần Thơ", Position: "1", Numbers: []string{"222"}},
+	}}
+	b := &Draw{Prizes: []Prize{
+		{Location: "TP.HCM", Position: "7", Numbers: []string{"11"}},
+		{Location: "TP.HCM", Position: "ĐB", Numbers: []string{"99999"}},
+		{Location: "Cần Thơ", Position: "1", Numbers: []string{"222"}},
+	}}
+
+	for i := 0; i < 20; i++ {
+		agreed, _ := reconcile([]*Draw{a, b})
+		if len(agreed) != 3 {
+			t.Fatalf("expected 3 agreed prizes, got %d: %v", len(agreed), agreed)
+		}
+		want := []prizeKey{
+			{"Cần Thơ", "1"},
+			{"TP.HCM", "ĐB"},
+			{"TP.HCM", "7"},
+		}
+		for j, p := range agreed {
+			got := prizeKey{p.Location, p.Position}
+			if got != want[j] {
+				t.Fatalf("run %d: agreed[%d] = %+v, want %+v", i, j, got, want[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package providers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/tuanitpro/kqxs/internal/httpclient"
+)
+
+var minhNgocRegionPaths = map[string]string{
+	"Miền Bắc":   "xsmb",
+	"Miền Trung": "xsmt",
+	"Miền Nam":   "xsmn",
+}
+
+// MinhNgoc scrapes the results table published on minhngoc.com.vn, used as
+// an independent cross-check against the RSS-based xosodaiphat source.
+type MinhNgoc struct {
+	client *httpclient.Client
+}
+
+// NewMinhNgoc builds a MinhNgoc provider using client for all requests.
+func NewMinhNgoc(client *httpclient.Client) MinhNgoc {
+	return MinhNgoc{client: client}
+}
+
+func (MinhNgoc) Name() string { return "minhngoc" }
+
+func (p MinhNgoc) Fetch(region string, date time.Time) (*Draw, error) {
+	path, ok := minhNgocRegionPaths[region]
+	if !ok {
+		return nil, fmt.Errorf("minhngoc: unknown region %q", region)
+	}
+
+	url := fmt.Sprintf("https://www.minhngoc.com.vn/ket-qua-xo-so/%s/%s.html", path, date.Format("02-01-2006"))
+	data, err := p.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var prizes []Prize
+	// Miền Bắc has a single station and minhngoc's table never repeats it
+	// as a header row, so default to it; Miền Trung/Miền Nam tables
+	// precede each station's rows with a "td.tinh" header that overrides
+	// this as the table is walked.
+	location := ""
+	if region == "Miền Bắc" {
+		location = "Hà Nội"
+	}
+	doc.Find("table.box_kqxs tr").Each(func(_ int, row *goquery.Selection) {
+		if station := strings.TrimSpace(row.Find("td.tinh").Text()); station != "" {
+			location = station
+			return
+		}
+		position := strings.TrimSpace(row.Find("td.ten_giai").Text())
+		if position == "" {
+			return
+		}
+		row.Find("td.giai_td div").Each(func(_ int, cell *goquery.Selection) {
+			number := strings.TrimSpace(cell.Text())
+			if number != "" {
+				prizes = append(prizes, Prize{Location: location, Position: position, Numbers: []string{number}})
+			}
+		})
+	})
+
+	if len(prizes) == 0 {
+		return nil, fmt.Errorf("minhngoc: no draw found for %s on %s", region, date.Format("2006-01-02"))
+	}
+
+	return &Draw{Region: region, Date: date, Prizes: prizes}, nil
+}
@@ -0,0 +1,40 @@
+package providers
+
+import "strings"
+
+// positionLabels maps minhngoc's full Vietnamese prize labels onto the
+// short codes xosodaiphat/xskt already use (parsed from "G.<code>" lines
+// by prizes.ParseLine), so all three sources key prizes the same way
+// during cross-validation.
+var positionLabels = map[string]string{
+	"giải đặc biệt": "ĐB",
+	"giải nhất":     "1",
+	"giải nhì":      "2",
+	"giải ba":       "3",
+	"giải tư":       "4",
+	"giải năm":      "5",
+	"giải sáu":      "6",
+	"giải bảy":      "7",
+	"giải tám":      "8",
+}
+
+// normalizePosition canonicalizes a prize position so sources using
+// different label conventions can be compared. Codes that already match
+// the short scheme pass through unchanged.
+func normalizePosition(raw string) string {
+	pos := strings.TrimSpace(raw)
+	if code, ok := positionLabels[strings.ToLower(pos)]; ok {
+		return code
+	}
+	return pos
+}
+
+// normalizeLocation canonicalizes a station name so a bracketed form
+// (xosodaiphat's "[Hà Nội]"), a raw form (xskt's JSON field), and an
+// already-clean form (minhngoc) all agree during cross-validation.
+func normalizeLocation(raw string) string {
+	loc := strings.TrimSpace(raw)
+	loc = strings.TrimPrefix(loc, "[")
+	loc = strings.TrimSuffix(loc, "]")
+	return strings.TrimSpace(loc)
+}
@@ -0,0 +1,30 @@
+// Package providers fetches lottery draws from multiple independent
+// sources so results can be cross-validated before they are published.
+package providers
+
+import "time"
+
+// Prize is one prize position (e.g. "ĐB", "1", "2"...) drawn for a
+// location, with every winning number reported under that position.
+type Prize struct {
+	Position string
+	Location string
+	Numbers  []string
+}
+
+// Draw is a single region's results for a single date.
+type Draw struct {
+	Region string
+	Date   time.Time
+	Prizes []Prize
+}
+
+// Provider fetches a Draw from one upstream source.
+type Provider interface {
+	Name() string
+	Fetch(region string, date time.Time) (*Draw, error)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
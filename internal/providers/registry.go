@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/tuanitpro/kqxs/internal/httpclient"
+)
+
+// Registry is a name-keyed set of registered Providers, selectable via
+// config (e.g. the KQXS_PROVIDERS env var).
+type Registry struct {
+	providers map[string]Provider
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{providers: make(map[string]Provider)}
+}
+
+// Register adds p under its own Name(), overwriting any previous
+// registration with the same name.
+func (r *Registry) Register(p Provider) {
+	r.providers[p.Name()] = p
+}
+
+// Get returns the provider registered under name, if any.
+func (r *Registry) Get(name string) (Provider, bool) {
+	p, ok := r.providers[name]
+	return p, ok
+}
+
+// Select returns the registered providers matching names, in the given
+// order, skipping any name that isn't registered.
+func (r *Registry) Select(names []string) []Provider {
+	var selected []Provider
+	for _, name := range names {
+		if p, ok := r.providers[name]; ok {
+			selected = append(selected, p)
+		}
+	}
+	return selected
+}
+
+// All returns every registered provider.
+func (r *Registry) All() []Provider {
+	all := make([]Provider, 0, len(r.providers))
+	for _, p := range r.providers {
+		all = append(all, p)
+	}
+	return all
+}
+
+// Default registers the three built-in providers, sharing a single
+// httpclient.Client configured from the environment (KQXS_PROXY), and
+// returns the registry.
+func Default() *Registry {
+	client, err := httpclient.FromEnv()
+	if err != nil {
+		fmt.Println("⚠️ invalid proxy config, falling back to direct connections:", err)
+		client, _ = httpclient.New(httpclient.Config{})
+	}
+
+	r := NewRegistry()
+	r.Register(NewXoSoDaiPhat(client))
+	r.Register(NewMinhNgoc(client))
+	r.Register(NewXoSoKienThiet(client))
+	return r
+}
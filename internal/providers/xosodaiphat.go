@@ -0,0 +1,91 @@
+package providers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+
+	"github.com/tuanitpro/kqxs/internal/httpclient"
+	"github.com/tuanitpro/kqxs/internal/prizes"
+)
+
+var xosodaiphatURLs = map[string]string{
+	"Miền Bắc":   "https://xosodaiphat.com/ket-qua-xo-so-mien-bac-xsmb.rss",
+	"Miền Trung": "https://xosodaiphat.com/ket-qua-xo-so-mien-trung-xsmt.rss",
+	"Miền Nam":   "https://xosodaiphat.com/ket-qua-xo-so-mien-nam-xsmn.rss",
+}
+
+// XoSoDaiPhat is the original RSS source, now fetched through the shared
+// httpclient (retries/cache/proxy) and parsed with the generic gofeed
+// parser instead of a bespoke encoding/xml struct.
+type XoSoDaiPhat struct {
+	client *httpclient.Client
+}
+
+// NewXoSoDaiPhat builds a XoSoDaiPhat provider using client for all
+// requests.
+func NewXoSoDaiPhat(client *httpclient.Client) XoSoDaiPhat {
+	return XoSoDaiPhat{client: client}
+}
+
+func (XoSoDaiPhat) Name() string { return "xosodaiphat" }
+
+func (p XoSoDaiPhat) Fetch(region string, date time.Time) (*Draw, error) {
+	url, ok := xosodaiphatURLs[region]
+	if !ok {
+		return nil, fmt.Errorf("xosodaiphat: unknown region %q", region)
+	}
+
+	data, err := p.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := gofeed.NewParser().ParseString(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if len(feed.Items) == 0 {
+		return nil, fmt.Errorf("xosodaiphat: no items for %s", region)
+	}
+
+	item := feed.Items[0]
+	if item.PublishedParsed != nil && !sameDay(*item.PublishedParsed, date) {
+		return nil, fmt.Errorf("xosodaiphat: no draw for %s on %s", region, date.Format("2006-01-02"))
+	}
+
+	return &Draw{
+		Region: region,
+		Date:   date,
+		Prizes: parsePrizeLines(item.Description),
+	}, nil
+}
+
+func parsePrizeLines(desc string) []Prize {
+	desc = strings.ReplaceAll(desc, "<br>", "\n")
+	desc = strings.ReplaceAll(desc, "<br/>", "\n")
+	desc = strings.ReplaceAll(desc, "<br />", "\n")
+
+	var parsed []Prize
+	location := ""
+	for _, line := range strings.Split(desc, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.Contains(line, "]") {
+			location = line
+			continue
+		}
+		if p, ok := prizes.ParseLine(line); ok {
+			parsed = append(parsed, Prize{
+				Location: location,
+				Position: p.Position,
+				Numbers:  p.Numbers,
+			})
+		}
+	}
+	return parsed
+}
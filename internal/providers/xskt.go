@@ -0,0 +1,64 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tuanitpro/kqxs/internal/httpclient"
+)
+
+var xsktRegionCodes = map[string]string{
+	"Miền Bắc":   "mb",
+	"Miền Trung": "mt",
+	"Miền Nam":   "mn",
+}
+
+type xsktResponse struct {
+	Prizes []struct {
+		Position string   `json:"position"`
+		Location string   `json:"location"`
+		Numbers  []string `json:"numbers"`
+	} `json:"prizes"`
+}
+
+// XoSoKienThiet talks to a JSON results API, used as the third
+// cross-validation source alongside the RSS and HTML-scraped ones.
+type XoSoKienThiet struct {
+	client *httpclient.Client
+}
+
+// NewXoSoKienThiet builds a XoSoKienThiet provider using client for all
+// requests.
+func NewXoSoKienThiet(client *httpclient.Client) XoSoKienThiet {
+	return XoSoKienThiet{client: client}
+}
+
+func (XoSoKienThiet) Name() string { return "xskt" }
+
+func (p XoSoKienThiet) Fetch(region string, date time.Time) (*Draw, error) {
+	code, ok := xsktRegionCodes[region]
+	if !ok {
+		return nil, fmt.Errorf("xskt: unknown region %q", region)
+	}
+
+	url := fmt.Sprintf("https://xskt.com.vn/api/ket-qua?region=%s&date=%s", code, date.Format("2006-01-02"))
+	data, err := p.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed xsktResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+	if len(parsed.Prizes) == 0 {
+		return nil, fmt.Errorf("xskt: no draw found for %s on %s", region, date.Format("2006-01-02"))
+	}
+
+	prizes := make([]Prize, 0, len(parsed.Prizes))
+	for _, pz := range parsed.Prizes {
+		prizes = append(prizes, Prize{Position: pz.Position, Location: pz.Location, Numbers: pz.Numbers})
+	}
+	return &Draw{Region: region, Date: date, Prizes: prizes}, nil
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// LoFrequency returns how many times each 2-digit "lô" (the last two
+// digits of every winning number) appeared for region over the last days
+// days, keyed by the 2-digit string.
+func (s *Store) LoFrequency(region string, days int) (map[string]int, error) {
+	since := time.Now().AddDate(0, 0, -days).Format("2006-01-02")
+
+	rows, err := s.db.Query(
+		`SELECT number FROM draws WHERE region = ? AND date >= ?`,
+		region, since,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	freq := make(map[string]int)
+	for rows.Next() {
+		var number string
+		if err := rows.Scan(&number); err != nil {
+			return nil, err
+		}
+		freq[lastTwoDigits(number)]++
+	}
+	return freq, rows.Err()
+}
+
+// GanEntry is a "số gan": a 2-digit lô and how many days it has been since
+// it last appeared.
+type GanEntry struct {
+	Lo        string
+	DaysSince int
+}
+
+// Gan returns every lô from 00-99 ordered by longest streak without
+// appearing first ("số gan lâu về nhất").
+func (s *Store) Gan(region string) ([]GanEntry, error) {
+	rows, err := s.db.Query(
+		`SELECT number, date FROM draws WHERE region = ? ORDER BY date DESC`,
+		region,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lastSeen := make(map[string]time.Time)
+	for rows.Next() {
+		var number, dateStr string
+		if err := rows.Scan(&number, &dateStr); err != nil {
+			return nil, err
+		}
+		lo := lastTwoDigits(number)
+		if _, ok := lastSeen[lo]; !ok {
+			date, err := time.Parse("2006-01-02", dateStr)
+			if err != nil {
+				continue
+			}
+			lastSeen[lo] = date
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entries := make([]GanEntry, 0, 100)
+	for i := 0; i < 100; i++ {
+		lo := twoDigit(i)
+		seen, ok := lastSeen[lo]
+		daysSince := -1 // never seen
+		if ok {
+			daysSince = int(now.Sub(seen).Hours() / 24)
+		}
+		entries = append(entries, GanEntry{Lo: lo, DaysSince: daysSince})
+	}
+
+	// Never-seen lô (-1) are the most overdue of all, so they must sort
+	// before every lô with a real streak, not after.
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].DaysSince, entries[j].DaysSince
+		if a == -1 {
+			a = math.MaxInt
+		}
+		if b == -1 {
+			b = math.MaxInt
+		}
+		return a > b
+	})
+	return entries, nil
+}
+
+// PairEntry is a pair of lô numbers that appeared together in the same
+// draw, with how often that has happened.
+type PairEntry struct {
+	A, B  string
+	Count int
+}
+
+// TopPairs returns the limit most frequent lô pairs (cặp) that showed up
+// together on the same draw date for region.
+func (s *Store) TopPairs(region string, limit int) ([]PairEntry, error) {
+	rows, err := s.db.Query(
+		`SELECT date, number FROM draws WHERE region = ? ORDER BY date`,
+		region,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byDate := make(map[string]map[string]bool)
+	for rows.Next() {
+		var dateStr, number string
+		if err := rows.Scan(&dateStr, &number); err != nil {
+			return nil, err
+		}
+		if byDate[dateStr] == nil {
+			byDate[dateStr] = make(map[string]bool)
+		}
+		byDate[dateStr][lastTwoDigits(number)] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[[2]string]int)
+	for _, los := range byDate {
+		unique := make([]string, 0, len(los))
+		for lo := range los {
+			unique = append(unique, lo)
+		}
+		sort.Strings(unique)
+		for i := 0; i < len(unique); i++ {
+			for j := i + 1; j < len(unique); j++ {
+				counts[[2]string{unique[i], unique[j]}]++
+			}
+		}
+	}
+
+	pairs := make([]PairEntry, 0, len(counts))
+	for k, count := range counts {
+		pairs = append(pairs, PairEntry{A: k[0], B: k[1], Count: count})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Count > pairs[j].Count })
+
+	if limit > 0 && len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+	return pairs, nil
+}
+
+func lastTwoDigits(number string) string {
+	if len(number) < 2 {
+		return number
+	}
+	return number[len(number)-2:]
+}
+
+func twoDigit(n int) string {
+	return fmt.Sprintf("%02d", n)
+}
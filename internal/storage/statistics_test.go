@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "draws.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestLoFrequency(t *testing.T) {
+	store := openTestStore(t)
+	today := time.Now()
+
+	records := []Record{
+		{Region: "Miền Bắc", Date: today, Station: "Hà Nội", PrizeName: "ĐB", Number: "12345"},
+		{Region: "Miền Bắc", Date: today, Station: "Hà Nội", PrizeName: "1", Number: "67845"},
+		{Region: "Miền Bắc", Date: today.AddDate(0, 0, -40), Station: "Hà Nội", PrizeName: "ĐB", Number: "99999"},
+	}
+	if err := store.SaveDraw(records); err != nil {
+		t.Fatalf("SaveDraw: %v", err)
+	}
+
+	freq, err := store.LoFrequency("Miền Bắc", 30)
+	if err != nil {
+		t.Fatalf("LoFrequency: %v", err)
+	}
+	if freq["45"] != 2 {
+		t.Fatalf("expected lô 45 to appear twice within the window, got %d", freq["45"])
+	}
+	if freq["99"] != 0 {
+		t.Fatalf("expected lô 99 outside the 30-day window to be excluded, got %d", freq["99"])
+	}
+}
+
+func TestGanSortsNeverSeenAsMostOverdue(t *testing.T) {
+	store := openTestStore(t)
+	today := time.Now()
+
+	if err := store.SaveDraw([]Record{
+		{Region: "Miền Bắc", Date: today, Station: "Hà Nội", PrizeName: "ĐB", Number: "00"},
+	}); err != nil {
+		t.Fatalf("SaveDraw: %v", err)
+	}
+
+	entries, err := store.Gan("Miền Bắc")
+	if err != nil {
+		t.Fatalf("Gan: %v", err)
+	}
+	if len(entries) != 100 {
+		t.Fatalf("expected 100 entries (00-99), got %d", len(entries))
+	}
+	if entries[0].DaysSince != -1 {
+		t.Fatalf("expected a never-seen lô first, got %+v", entries[0])
+	}
+	last := entries[len(entries)-1]
+	if last.Lo != "00" || last.DaysSince != 0 {
+		t.Fatalf("expected lô 00 (seen today) last, got %+v", last)
+	}
+}
+
+func TestTopPairs(t *testing.T) {
+	store := openTestStore(t)
+	today := time.Now()
+
+	if err := store.SaveDraw([]Record{
+		{Region: "Miền Nam", Date: today, Station: "TP.HCM", PrizeName: "ĐB", Number: "27"},
+		{Region: "Miền Nam", Date: today, Station: "TP.HCM", PrizeName: "1", Number: "39"},
+		{Region: "Miền Nam", Date: today.AddDate(0, 0, -1), Station: "TP.HCM", PrizeName: "ĐB", Number: "27"},
+		{Region: "Miền Nam", Date: today.AddDate(0, 0, -1), Station: "TP.HCM", PrizeName: "1", Number: "39"},
+	}); err != nil {
+		t.Fatalf("SaveDraw: %v", err)
+	}
+
+	pairs, err := store.TopPairs("Miền Nam", 1)
+	if err != nil {
+		t.Fatalf("TopPairs: %v", err)
+	}
+	if len(pairs) != 1 {
+		t.Fatalf("expected 1 pair (limit), got %d", len(pairs))
+	}
+	if pairs[0].A != "27" || pairs[0].B != "39" || pairs[0].Count != 2 {
+		t.Fatalf("unexpected top pair: %+v", pairs[0])
+	}
+}
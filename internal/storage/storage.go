@@ -0,0 +1,104 @@
+// Package storage persists parsed draws to SQLite so results accumulate
+// over time instead of being discarded after each notification.
+package storage
+
+import (
+	"database/sql"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS draws (
+	region     TEXT NOT NULL,
+	date       TEXT NOT NULL,
+	station    TEXT NOT NULL,
+	prize_name TEXT NOT NULL,
+	number     TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_draws_region_date ON draws(region, date);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_draws_unique ON draws(region, date, station, prize_name, number);
+`
+
+// Record is one winning number, ready to persist.
+type Record struct {
+	Region    string
+	Date      time.Time
+	Station   string
+	PrizeName string
+	Number    string
+}
+
+// Store wraps the SQLite draw history database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the SQLite database at path and
+// ensures the schema exists.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SaveDraw persists every record in a single transaction, ignoring
+// duplicates so re-fetching the same draw is a no-op.
+func (s *Store) SaveDraw(records []Record) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO draws (region, date, station, prize_name, number) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range records {
+		if _, err := stmt.Exec(r.Region, r.Date.Format("2006-01-02"), r.Station, r.PrizeName, r.Number); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// History returns every record stored for region on date.
+func (s *Store) History(region string, date time.Time) ([]Record, error) {
+	rows, err := s.db.Query(
+		`SELECT region, date, station, prize_name, number FROM draws WHERE region = ? AND date = ?`,
+		region, date.Format("2006-01-02"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var r Record
+		var dateStr string
+		if err := rows.Scan(&r.Region, &dateStr, &r.Station, &r.PrizeName, &r.Number); err != nil {
+			return nil, err
+		}
+		r.Date, _ = time.Parse("2006-01-02", dateStr)
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
@@ -2,37 +2,39 @@ package main
 
 import (
 	"bytes"
-	"encoding/xml"
 	"flag"
 	"fmt"
-	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/robfig/cron/v3"
+
+	"github.com/tuanitpro/kqxs/internal/formatter"
+	"github.com/tuanitpro/kqxs/internal/providers"
+	"github.com/tuanitpro/kqxs/internal/storage"
 )
 
-type RSS struct {
-	Channel struct {
-		Items []struct {
-			Title       string `xml:"title"`
-			Description string `xml:"description"`
-			PubDate     string `xml:"pubDate"`
-		} `xml:"item"`
-	} `xml:"channel"`
-}
+var regions = []string{"Miền Bắc", "Miền Trung", "Miền Nam"}
 
-var rssURLs = map[string]string{
-	"Miền Bắc":   "https://xosodaiphat.com/ket-qua-xo-so-mien-bac-xsmb.rss",
-	"Miền Trung": "https://xosodaiphat.com/ket-qua-xo-so-mien-trung-xsmt.rss",
-	"Miền Nam":   "https://xosodaiphat.com/ket-qua-xo-so-mien-nam-xsmn.rss",
+// regionAliases maps the short commands users type (/mb, /mt, /mn) to the
+// region names used throughout the app.
+var regionAliases = map[string]string{
+	"mb": "Miền Bắc",
+	"mt": "Miền Trung",
+	"mn": "Miền Nam",
 }
 
 var telegramBotToken string
 var telegramChatID string
+var providerRegistry = providers.Default()
+var selectedProviders []providers.Provider
+var selectedFormatter formatter.Formatter
+var store *storage.Store
 
 func init() {
 	// Load .env
@@ -44,67 +46,111 @@ func init() {
 	telegramBotToken = os.Getenv("TELEGRAM_TOKEN")
 	telegramChatID = os.Getenv("TELEGRAM_TO")
 
-	if telegramBotToken == "" || telegramChatID == "" {
-		fmt.Println("❌ TELEGRAM_TOKEN or TELEGRAM_TO is missing")
+	if telegramBotToken == "" {
+		fmt.Println("❌ TELEGRAM_TOKEN is missing")
 		os.Exit(1)
 	}
+
+	selectedProviders = resolveProviders(os.Getenv("KQXS_PROVIDERS"))
+	selectedFormatter = formatter.FromEnv()
 }
 
-func fetchRSS(url string) (*RSS, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+// resolveProviders parses the comma-separated KQXS_PROVIDERS env var,
+// falling back to every registered provider so cross-validation works
+// out of the box.
+func resolveProviders(env string) []providers.Provider {
+	if env == "" {
+		return providerRegistry.All()
 	}
-	defer resp.Body.Close()
+	return providerRegistry.Select(strings.Split(env, ","))
+}
 
-	data, err := io.ReadAll(resp.Body)
+// FetchResults cross-validates region's draw across every configured
+// provider and returns only the prize numbers at least 2 sources agree on,
+// plus any cross-validation warnings (disagreements, or a position with no
+// consensus at all) that callers must surface to the chat requesting the
+// result rather than just logging.
+// Every successfully fetched draw is persisted so /thongke, /gan and /cap
+// have history to work from.
+func FetchResults(region string, date time.Time) ([]providers.Prize, []string, error) {
+	result, err := providers.FetchConsensus(selectedProviders, region, date)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-
-	var rss RSS
-	if err := xml.Unmarshal(data, &rss); err != nil {
-		return nil, err
+	for _, w := range result.Warnings {
+		fmt.Println(w)
 	}
-	return &rss, nil
-}
-
-func parseDescription(desc string) map[string][]string {
-	desc = strings.ReplaceAll(desc, "<br>", "\n")
-	desc = strings.ReplaceAll(desc, "<br/>", "\n")
-	desc = strings.ReplaceAll(desc, "<br />", "\n")
 
-	lines := strings.Split(desc, "\n")
+	if err := saveDraw(region, date, result.Draw.Prizes); err != nil {
+		fmt.Println("⚠️ Cannot persist draw:", err)
+	}
 
-	results := make(map[string][]string)
-	currentLocation := ""
+	return result.Draw.Prizes, result.Warnings, nil
+}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
+func saveDraw(region string, date time.Time, prizes []providers.Prize) error {
+	if store == nil {
+		return nil
+	}
 
-		// Location
-		if strings.HasPrefix(line, "[") && strings.Contains(line, "]") {
-			currentLocation = line
-			continue
+	var records []storage.Record
+	for _, p := range prizes {
+		for _, number := range p.Numbers {
+			records = append(records, storage.Record{
+				Region:    region,
+				Date:      date,
+				Station:   p.Location,
+				PrizeName: p.Position,
+				Number:    number,
+			})
 		}
+	}
+	return store.SaveDraw(records)
+}
 
-		// Giải thưởng
-		if strings.HasPrefix(line, "G.") {
-			results[currentLocation] = append(results[currentLocation], line)
-		}
+// sendToTelegram posts message to chatID via sendMessage. parseMode is
+// Telegram's parse_mode ("MarkdownV2" or "" for plain text).
+func sendToTelegram(chatID, message, parseMode string) error {
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", telegramBotToken)
+	form := url.Values{"chat_id": {chatID}, "text": {message}}
+	if parseMode != "" {
+		form.Set("parse_mode", parseMode)
 	}
 
-	return results
+	resp, err := http.PostForm(endpoint, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
 }
 
-func sendToTelegram(message string) error {
-	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", telegramBotToken)
-	payload := fmt.Sprintf("chat_id=%s&text=%s", telegramChatID, message)
+// sendPhotoToTelegram posts photo to chatID via sendPhoto, with caption as
+// the accompanying text.
+func sendPhotoToTelegram(chatID string, photo []byte, caption string) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("chat_id", chatID); err != nil {
+		return err
+	}
+	if caption != "" {
+		if err := writer.WriteField("caption", caption); err != nil {
+			return err
+		}
+	}
+	part, err := writer.CreateFormFile("photo", "result.png")
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(photo); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
 
-	resp, err := http.Post(url, "application/x-www-form-urlencoded", bytes.NewBufferString(payload))
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/sendPhoto", telegramBotToken)
+	resp, err := http.Post(endpoint, writer.FormDataContentType(), &body)
 	if err != nil {
 		return err
 	}
@@ -112,55 +158,99 @@ func sendToTelegram(message string) error {
 	return nil
 }
 
+// deliver sends every message the formatter produced to chatID, using
+// sendPhoto for image messages and sendMessage otherwise.
+func deliver(chatID string, messages []formatter.Message) {
+	for _, m := range messages {
+		var err error
+		if m.Image != nil {
+			err = sendPhotoToTelegram(chatID, m.Image, m.Text)
+		} else {
+			err = sendToTelegram(chatID, m.Text, m.ParseMode)
+		}
+		if err != nil {
+			fmt.Println("❌ Telegram send error:", err)
+		}
+	}
+}
+
+// runJob fetches today's draw for every region and fans it out to every
+// subscribed chat (falling back to TELEGRAM_TO if no one has subscribed
+// yet), sending one message per region so a single day's results never
+// risk exceeding Telegram's message size limit.
 func runJob() {
-	var finalMessage strings.Builder
-	finalMessage.WriteString("🎰 *Kết quả xổ số hôm nay*\n\n")
+	today := time.Now()
+
+	type regionMessages struct {
+		region   string
+		messages []formatter.Message
+	}
+	var perRegion []regionMessages
 
-	for region, url := range rssURLs {
-		rss, err := fetchRSS(url)
+	for _, region := range regions {
+		prizes, warnings, err := FetchResults(region, today)
 		if err != nil {
-			fmt.Println("Error fetching:", err)
+			fmt.Println("Error fetching:", region, err)
 			continue
 		}
-		if len(rss.Channel.Items) == 0 {
-			fmt.Println("No items found for", region)
+		notifyWatchers(region, today, prizes)
+
+		messages, err := selectedFormatter.Format(region, today, prizes)
+		if err != nil {
+			fmt.Println("⚠️ Cannot format", region, ":", err)
 			continue
 		}
-
-		item := rss.Channel.Items[0]
-
-		prizesByLocation := parseDescription(item.Description)
-
-		fmt.Printf("=== %s | %s ===\n", region, item.Title)
-		finalMessage.WriteString(fmt.Sprintf("📢 %s - %s\n", region, item.Title))
-
-		for loc, prizes := range prizesByLocation {
-			if loc != "" {
-				fmt.Println(loc)
-				finalMessage.WriteString(fmt.Sprintf("%s\n", loc))
-			}
-			for _, p := range prizes {
-				fmt.Println(p)
-				finalMessage.WriteString(fmt.Sprintf("%s\n", p))
-			}
-			fmt.Println()
-			finalMessage.WriteString("\n")
+		if len(warnings) > 0 {
+			messages = append(messages, formatter.Message{Text: strings.Join(warnings, "\n")})
 		}
+		perRegion = append(perRegion, regionMessages{region: region, messages: messages})
+	}
+
+	chatIDs := subscribedChatIDs()
+	if len(chatIDs) == 0 && telegramChatID != "" {
+		chatIDs = []string{telegramChatID}
 	}
 
-	// Gửi message
-	msg := finalMessage.String()
-	if err := sendToTelegram(msg); err != nil {
-		fmt.Println("Telegram error:", err)
-	} else {
-		fmt.Println("✅ Sent to Telegram successfully")
+	for _, chatID := range chatIDs {
+		for _, rm := range perRegion {
+			deliver(chatID, rm.messages)
+		}
+		fmt.Println("✅ Sent to Telegram successfully:", chatID)
 	}
 }
 
 func main() {
 	runNow := flag.Bool("now", false, "Run the job immediately without waiting for schedule")
+	backfill := flag.String("backfill", "", "Backfill draw history, e.g. --backfill from=2024-01-01 to=2024-01-31 (to defaults to today)")
 	flag.Parse()
 
+	if err := openSubscriptionStore(); err != nil {
+		fmt.Println("❌ Cannot open subscription store:", err)
+		os.Exit(1)
+	}
+	defer closeSubscriptionStore()
+
+	db, err := storage.Open("draws.db")
+	if err != nil {
+		fmt.Println("❌ Cannot open draw history store:", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+	store = db
+
+	if *backfill != "" {
+		from, to, err := parseBackfillArgs(*backfill)
+		if err != nil {
+			fmt.Println("❌ Invalid --backfill:", err)
+			os.Exit(1)
+		}
+		if err := runBackfill(from, to); err != nil {
+			fmt.Println("❌ Backfill failed:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if *runNow {
 		fmt.Println("🚀 Running job immediately (--now)")
 		runJob()
@@ -186,6 +276,8 @@ func main() {
 	fmt.Println("⏰ Scheduler started... Waiting for 18:30 Asia/Ho_Chi_Minh")
 	c.Start()
 
+	go runBot()
+
 	// Giữ chương trình chạy
 	select {}
 }
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resolveRegionArg maps a user-typed region shorthand (mb/mt/mn, any case)
+// to the full region name used everywhere else in the app.
+func resolveRegionArg(arg string) (string, bool) {
+	region, ok := regionAliases[strings.ToLower(arg)]
+	return region, ok
+}
+
+func handleThongKeLo(chatID, daysArg string) {
+	days, err := strconv.Atoi(daysArg)
+	if err != nil || days <= 0 {
+		reply(chatID, "❌ Số ngày không hợp lệ")
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("📊 Thống kê lô %d ngày gần nhất:\n\n", days))
+
+	for _, region := range regions {
+		freq, err := store.LoFrequency(region, days)
+		if err != nil {
+			reply(chatID, fmt.Sprintf("❌ Lỗi thống kê %s: %s", region, err))
+			return
+		}
+		b.WriteString(region + ":\n")
+		b.WriteString(formatFrequency(freq))
+		b.WriteString("\n")
+	}
+	reply(chatID, b.String())
+}
+
+func formatFrequency(freq map[string]int) string {
+	type entry struct {
+		lo    string
+		count int
+	}
+	entries := make([]entry, 0, len(freq))
+	for lo, count := range freq {
+		entries = append(entries, entry{lo, count})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].count > entries[j].count })
+
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(fmt.Sprintf("%s: %d lần\n", e.lo, e.count))
+	}
+	return b.String()
+}
+
+func handleGan(chatID, regionArg string) {
+	region, ok := resolveRegionArg(regionArg)
+	if !ok {
+		reply(chatID, "❌ Miền không hợp lệ, dùng MB/MT/MN")
+		return
+	}
+
+	entries, err := store.Gan(region)
+	if err != nil {
+		reply(chatID, fmt.Sprintf("❌ Lỗi lấy số gan %s: %s", region, err))
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("🥶 Số gan %s (lâu chưa về nhất):\n\n", region))
+	for i, e := range entries {
+		if i >= 10 {
+			break
+		}
+		if e.DaysSince < 0 {
+			b.WriteString(fmt.Sprintf("%s: chưa từng về\n", e.Lo))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("%s: %d ngày\n", e.Lo, e.DaysSince))
+	}
+	reply(chatID, b.String())
+}
+
+func handleCap(chatID, regionArg string) {
+	region, ok := resolveRegionArg(regionArg)
+	if !ok {
+		reply(chatID, "❌ Miền không hợp lệ, dùng MB/MT/MN")
+		return
+	}
+
+	pairs, err := store.TopPairs(region, 10)
+	if err != nil {
+		reply(chatID, fmt.Sprintf("❌ Lỗi lấy cặp số %s: %s", region, err))
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("🔗 Cặp số hay về cùng nhau %s:\n\n", region))
+	for _, p := range pairs {
+		b.WriteString(fmt.Sprintf("%s - %s: %d lần\n", p.A, p.B, p.Count))
+	}
+	reply(chatID, b.String())
+}
+
+func handleHistory(chatID, dateArg string) {
+	date, err := time.Parse("02/01", dateArg)
+	if err != nil {
+		reply(chatID, "❌ Ngày không hợp lệ, dùng định dạng dd/mm")
+		return
+	}
+	date = date.AddDate(time.Now().Year(), 0, 0)
+	if date.After(time.Now()) {
+		// dd/mm fell later in the calendar than today (e.g. /history 27/12
+		// in July): the intended date is almost certainly last year's.
+		date = date.AddDate(-1, 0, 0)
+	}
+
+	var b strings.Builder
+	found := false
+	for _, region := range regions {
+		records, err := store.History(region, date)
+		if err != nil {
+			reply(chatID, fmt.Sprintf("❌ Lỗi lấy lịch sử %s: %s", region, err))
+			return
+		}
+		if len(records) == 0 {
+			continue
+		}
+		found = true
+		b.WriteString(fmt.Sprintf("📢 %s - %s\n\n", region, date.Format("02/01/2006")))
+		for _, r := range records {
+			b.WriteString(fmt.Sprintf("[%s] G.%s %s\n", r.Station, r.PrizeName, r.Number))
+		}
+		b.WriteString("\n")
+	}
+
+	if !found {
+		reply(chatID, "⚠️ Không có dữ liệu đã lưu cho ngày này")
+		return
+	}
+	reply(chatID, b.String())
+}
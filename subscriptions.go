@@ -0,0 +1,59 @@
+package main
+
+import (
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var subscriptionsBucket = []byte("subscriptions")
+
+var subscriptionDB *bbolt.DB
+
+func openSubscriptionStore() error {
+	db, err := bbolt.Open("subscriptions.db", 0600, &bbolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(subscriptionsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return err
+	}
+
+	subscriptionDB = db
+	return nil
+}
+
+func closeSubscriptionStore() {
+	if subscriptionDB != nil {
+		subscriptionDB.Close()
+	}
+}
+
+func subscribe(chatID string) error {
+	return subscriptionDB.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(subscriptionsBucket).Put([]byte(chatID), []byte("1"))
+	})
+}
+
+func unsubscribe(chatID string) error {
+	return subscriptionDB.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(subscriptionsBucket).Delete([]byte(chatID))
+	})
+}
+
+func subscribedChatIDs() []string {
+	var chatIDs []string
+	subscriptionDB.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(subscriptionsBucket).ForEach(func(k, v []byte) error {
+			chatIDs = append(chatIDs, string(k))
+			return nil
+		})
+	})
+	return chatIDs
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tuanitpro/kqxs/internal/prizes"
+	"github.com/tuanitpro/kqxs/internal/providers"
+)
+
+// regionAbbrev maps a full region name back to the short form used by the
+// /mb-style commands and in watch alerts.
+func regionAbbrev(region string) string {
+	for abbr, name := range regionAliases {
+		if name == region {
+			return strings.ToUpper(abbr)
+		}
+	}
+	return region
+}
+
+// defaultWatchRegion is used when /watch is called without a region,
+// since Miền Bắc is the single most commonly watched region.
+const defaultWatchRegion = "Miền Bắc"
+
+func handleWatch(chatID string, args []string) {
+	if len(args) == 0 {
+		reply(chatID, "Cú pháp: /watch [miền] [dau|duoi|lo2|lo3] <số...>, VD: /watch MB 27 39 88")
+		return
+	}
+
+	region := defaultWatchRegion
+	if r, ok := resolveRegionArg(args[0]); ok {
+		region = r
+		args = args[1:]
+	}
+
+	mode := prizes.ModeLo2
+	numbers := args
+	if len(args) > 0 {
+		if m := prizes.MatchMode(args[0]); prizes.IsValidMode(m) {
+			mode = m
+			numbers = args[1:]
+		}
+	}
+	if len(numbers) == 0 {
+		reply(chatID, "❌ Thiếu số cần theo dõi")
+		return
+	}
+	for _, n := range numbers {
+		if _, err := strconv.Atoi(n); err != nil {
+			reply(chatID, fmt.Sprintf("❌ Số không hợp lệ: %s", n))
+			return
+		}
+	}
+
+	if err := addWatch(chatID, region, mode, numbers); err != nil {
+		reply(chatID, "❌ Không thể lưu theo dõi: "+err.Error())
+		return
+	}
+	reply(chatID, fmt.Sprintf("✅ Đang theo dõi %s (%s): %s", regionAbbrev(region), mode, strings.Join(numbers, ", ")))
+}
+
+func handleUnwatch(chatID string, args []string) {
+	if len(args) == 0 {
+		reply(chatID, "Cú pháp: /unwatch <số...>")
+		return
+	}
+	if err := removeWatch(chatID, args); err != nil {
+		reply(chatID, "❌ Không thể hủy theo dõi: "+err.Error())
+		return
+	}
+	reply(chatID, "✅ Đã hủy theo dõi: "+strings.Join(args, ", "))
+}
+
+func handleWatchlist(chatID string) {
+	entries, err := watchesFor(chatID)
+	if err != nil {
+		reply(chatID, "❌ Không thể lấy danh sách theo dõi: "+err.Error())
+		return
+	}
+	if len(entries) == 0 {
+		reply(chatID, "📭 Bạn chưa theo dõi số nào")
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("👀 Danh sách theo dõi:\n\n")
+	for _, e := range entries {
+		b.WriteString(fmt.Sprintf("%s - %s (%s)\n", regionAbbrev(e.Region), e.Number, e.Mode))
+	}
+	reply(chatID, b.String())
+}
+
+// notifyWatchers diffs a freshly fetched draw against every chat's
+// watchlist and alerts anyone whose number matched.
+func notifyWatchers(region string, date time.Time, list []providers.Prize) {
+	watches, err := allWatches()
+	if err != nil {
+		fmt.Println("⚠️ Cannot load watchlists:", err)
+		return
+	}
+	if len(watches) == 0 {
+		return
+	}
+
+	for chatID, entries := range watches {
+		for _, p := range list {
+			for _, number := range p.Numbers {
+				for _, e := range entries {
+					if e.Region != region {
+						continue
+					}
+					if !prizes.Matches(e.Mode, e.Number, number) {
+						continue
+					}
+					reply(chatID, fmt.Sprintf(
+						"🎉 Số %s của bạn về giải %s đài %s (%s %s)",
+						e.Number, p.Position, p.Location, regionAbbrev(region), date.Format("02/01"),
+					))
+				}
+			}
+		}
+	}
+}
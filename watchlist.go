@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/tuanitpro/kqxs/internal/prizes"
+)
+
+var watchlistBucket = []byte("watchlist")
+
+// WatchEntry is one number a chat wants to be alerted about for a given
+// region, and the mode used to compare it against winning numbers.
+type WatchEntry struct {
+	Region string           `json:"region"`
+	Mode   prizes.MatchMode `json:"mode"`
+	Number string           `json:"number"`
+}
+
+// addWatch appends numbers under mode, scoped to region, to chatID's
+// watchlist, skipping any that are already watched under the same
+// region+mode.
+func addWatch(chatID, region string, mode prizes.MatchMode, numbers []string) error {
+	return subscriptionDB.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(watchlistBucket)
+		if err != nil {
+			return err
+		}
+		entries, err := decodeWatchEntries(bucket.Get([]byte(chatID)))
+		if err != nil {
+			return err
+		}
+
+		existing := make(map[WatchEntry]bool, len(entries))
+		for _, e := range entries {
+			existing[e] = true
+		}
+		for _, n := range numbers {
+			e := WatchEntry{Region: region, Mode: mode, Number: n}
+			if !existing[e] {
+				entries = append(entries, e)
+				existing[e] = true
+			}
+		}
+
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(chatID), data)
+	})
+}
+
+// removeWatch drops every entry for chatID whose number is in numbers,
+// regardless of mode.
+func removeWatch(chatID string, numbers []string) error {
+	drop := make(map[string]bool, len(numbers))
+	for _, n := range numbers {
+		drop[n] = true
+	}
+
+	return subscriptionDB.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(watchlistBucket)
+		if err != nil {
+			return err
+		}
+		entries, err := decodeWatchEntries(bucket.Get([]byte(chatID)))
+		if err != nil {
+			return err
+		}
+
+		var kept []WatchEntry
+		for _, e := range entries {
+			if !drop[e.Number] {
+				kept = append(kept, e)
+			}
+		}
+
+		data, err := json.Marshal(kept)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(chatID), data)
+	})
+}
+
+// watchesFor returns chatID's current watchlist.
+func watchesFor(chatID string) ([]WatchEntry, error) {
+	var entries []WatchEntry
+	err := subscriptionDB.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(watchlistBucket)
+		if bucket == nil {
+			return nil
+		}
+		var err error
+		entries, err = decodeWatchEntries(bucket.Get([]byte(chatID)))
+		return err
+	})
+	return entries, err
+}
+
+// allWatches returns every chat's watchlist, keyed by chat ID, so the
+// scheduler can diff it against each freshly fetched draw.
+func allWatches() (map[string][]WatchEntry, error) {
+	all := make(map[string][]WatchEntry)
+	err := subscriptionDB.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(watchlistBucket)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			entries, err := decodeWatchEntries(v)
+			if err != nil {
+				return err
+			}
+			if len(entries) > 0 {
+				all[string(k)] = entries
+			}
+			return nil
+		})
+	})
+	return all, err
+}
+
+func decodeWatchEntries(data []byte) ([]WatchEntry, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var entries []WatchEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}